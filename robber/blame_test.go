@@ -0,0 +1,24 @@
+package robber
+
+import "testing"
+
+func TestSecretStillPresent(t *testing.T) {
+	cases := []struct {
+		name     string
+		lineText string
+		secret   string
+		want     bool
+	}{
+		{"secret unchanged", `token := "sk-abc123"`, "sk-abc123", true},
+		{"secret rotated out", `token := "sk-rotated"`, "sk-abc123", false},
+		{"line deleted", "", "sk-abc123", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := secretStillPresent(c.lineText, c.secret); got != c.want {
+				t.Errorf("secretStillPresent(%q, %q) = %v, want %v", c.lineText, c.secret, got, c.want)
+			}
+		})
+	}
+}
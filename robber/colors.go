@@ -0,0 +1,279 @@
+package robber
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+)
+
+var levelNames = map[string]int{
+	"verbose": verbose,
+	"secret":  secret,
+	"info":    info,
+	"data":    data,
+	"succ":    succ,
+	"warn":    warn,
+	"fail":    fail,
+}
+
+var fgAttrs = map[string]color.Attribute{
+	"black":     color.FgBlack,
+	"blue":      color.FgBlue,
+	"cyan":      color.FgCyan,
+	"green":     color.FgGreen,
+	"magenta":   color.FgMagenta,
+	"red":       color.FgRed,
+	"white":     color.FgWhite,
+	"yellow":    color.FgYellow,
+	"hiBlack":   color.FgHiBlack,
+	"hiBlue":    color.FgHiBlue,
+	"hiCyan":    color.FgHiCyan,
+	"hiGreen":   color.FgHiGreen,
+	"hiMagenta": color.FgHiMagenta,
+	"hiRed":     color.FgHiRed,
+	"hiWhite":   color.FgHiWhite,
+	"hiYellow":  color.FgHiYellow,
+}
+
+var bgAttrs = map[string]color.Attribute{
+	"black":   color.BgBlack,
+	"blue":    color.BgBlue,
+	"cyan":    color.BgCyan,
+	"green":   color.BgGreen,
+	"magenta": color.BgMagenta,
+	"red":     color.BgRed,
+	"white":   color.BgWhite,
+	"yellow":  color.BgYellow,
+}
+
+var styleAttrs = map[string]color.Attribute{
+	"bold":      color.Bold,
+	"underline": color.Underline,
+	"italic":    color.Italic,
+}
+
+// colorStyle is a single entry under a config file's colors: section. It
+// accepts either a style string ("hiRed bold") or a structured
+// {fg, bg, attrs} mapping, mirroring the pattern RRH uses with a Color
+// struct wrapping a settings map plus resolved funcs.
+type colorStyle struct {
+	raw        string
+	fg         string
+	bg         string
+	attrs      []string
+	structured bool
+}
+
+func (c *colorStyle) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err == nil {
+		c.raw = s
+		return nil
+	}
+
+	var structured struct {
+		FG    string   `yaml:"fg"`
+		BG    string   `yaml:"bg"`
+		Attrs []string `yaml:"attrs"`
+	}
+	if err := value.Decode(&structured); err != nil {
+		return err
+	}
+	c.fg = structured.FG
+	c.bg = structured.BG
+	c.attrs = structured.Attrs
+	c.structured = true
+	return nil
+}
+
+// colorsFileConfig is the shape of ~/.yar/config.yaml.
+type colorsFileConfig struct {
+	Theme  string                `yaml:"theme"`
+	Colors map[string]colorStyle `yaml:"colors"`
+}
+
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".yar", "config.yaml")
+}
+
+func loadColorsFileConfig(path string) (*colorsFileConfig, error) {
+	cfg := &colorsFileConfig{}
+	if path == "" {
+		return cfg, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func resolveStyle(style colorStyle) (*color.Color, bool) {
+	if !style.structured {
+		fields := strings.Fields(style.raw)
+		if len(fields) == 0 {
+			return nil, false
+		}
+		val, ok := validColors[fields[0]]
+		if !ok {
+			return nil, false
+		}
+		if len(fields) > 1 && fields[1] == "bold" {
+			return val.Add(color.Bold), true
+		}
+		return val, true
+	}
+
+	var attrs []color.Attribute
+	if style.fg != "" {
+		fg, ok := fgAttrs[style.fg]
+		if !ok {
+			return nil, false
+		}
+		attrs = append(attrs, fg)
+	}
+	if style.bg != "" {
+		bg, ok := bgAttrs[style.bg]
+		if !ok {
+			return nil, false
+		}
+		attrs = append(attrs, bg)
+	}
+	for _, a := range style.attrs {
+		attr, ok := styleAttrs[a]
+		if !ok {
+			return nil, false
+		}
+		attrs = append(attrs, attr)
+	}
+	return color.New(attrs...), true
+}
+
+func themeDefault() map[int]*color.Color {
+	return map[int]*color.Color{
+		verbose: color.New(color.FgBlue),
+		secret:  color.New(color.FgHiYellow).Add(color.Bold),
+		info:    color.New(color.FgHiWhite),
+		data:    color.New(color.FgHiBlue),
+		succ:    color.New(color.FgGreen),
+		warn:    color.New(color.FgRed),
+		fail:    color.New(color.FgRed).Add(color.Bold),
+	}
+}
+
+func themeLight() map[int]*color.Color {
+	return map[int]*color.Color{
+		verbose: color.New(color.FgBlue),
+		secret:  color.New(color.FgMagenta).Add(color.Bold),
+		info:    color.New(color.FgBlack),
+		data:    color.New(color.FgBlue),
+		succ:    color.New(color.FgGreen),
+		warn:    color.New(color.FgRed),
+		fail:    color.New(color.FgRed).Add(color.Bold),
+	}
+}
+
+func themeMono() map[int]*color.Color {
+	plain := color.New(color.Reset)
+	return map[int]*color.Color{
+		verbose: plain,
+		secret:  plain,
+		info:    plain,
+		data:    plain,
+		succ:    plain,
+		warn:    plain,
+		fail:    plain,
+	}
+}
+
+var builtinThemes = map[string]func() map[int]*color.Color{
+	"default": themeDefault,
+	"light":   themeLight,
+	"mono":    themeMono,
+}
+
+// setColors resolves the logger's color scheme in increasing order of
+// precedence: the built-in/config-file theme, the config file's colors:
+// overrides, then YAR_* env vars, which always win. --no-color and
+// NO_COLOR disable color outright regardless of theme.
+func (l *Logger) setColors(flags *Flags) {
+	themeName := "default"
+	configPath := defaultConfigPath()
+	if flags != nil && flags.Config != nil && *flags.Config != "" {
+		configPath = *flags.Config
+	}
+
+	fileCfg, err := loadColorsFileConfig(configPath)
+	if err != nil {
+		l.LogWarn("could not load color config %s: %v\n", configPath, err)
+		fileCfg = &colorsFileConfig{}
+	}
+	if fileCfg.Theme != "" {
+		themeName = fileCfg.Theme
+	}
+	if flags != nil && flags.Theme != nil && *flags.Theme != "" {
+		themeName = *flags.Theme
+	}
+
+	build, ok := builtinThemes[themeName]
+	if !ok {
+		l.LogWarn("unknown theme %q, falling back to default\n", themeName)
+		build = themeDefault
+	}
+	logColors = build()
+
+	for name, style := range fileCfg.Colors {
+		level, ok := levelNames[name]
+		if !ok {
+			l.LogWarn("unknown color level %q in config\n", name)
+			continue
+		}
+		c, ok := resolveStyle(style)
+		if !ok {
+			l.LogWarn("unknown color in config for level %q\n", name)
+			continue
+		}
+		logColors[level] = c
+	}
+
+	envColors := GetEnvColors()
+	for colorType := verbose; colorType <= fail; colorType++ {
+		if envColors[colorType] == "" {
+			continue
+		}
+		fields := strings.Fields(envColors[colorType])
+		val, ok := validColors[fields[0]]
+		if !ok {
+			l.LogWarn("unknown color %q in environment override\n", fields[0])
+			continue
+		}
+		if len(fields) > 1 && fields[1] == "bold" {
+			logColors[colorType] = val.Add(color.Bold)
+			continue
+		}
+		logColors[colorType] = val
+	}
+
+	noColor := themeName == "mono" || os.Getenv("NO_COLOR") != ""
+	if flags != nil && flags.NoColor != nil && *flags.NoColor {
+		noColor = true
+	}
+	if noColor {
+		color.NoColor = true
+	}
+}
@@ -0,0 +1,32 @@
+package robber
+
+import "testing"
+
+func TestResolveStyleRawString(t *testing.T) {
+	if _, ok := resolveStyle(colorStyle{raw: "hiRed bold"}); !ok {
+		t.Fatal("expected \"hiRed bold\" to resolve")
+	}
+	if _, ok := resolveStyle(colorStyle{raw: "notacolor"}); ok {
+		t.Fatal("expected an unknown color name to fail to resolve")
+	}
+	if _, ok := resolveStyle(colorStyle{raw: ""}); ok {
+		t.Fatal("expected an empty style to fail to resolve")
+	}
+}
+
+func TestResolveStyleStructured(t *testing.T) {
+	style := colorStyle{structured: true, fg: "hiYellow", bg: "black", attrs: []string{"bold"}}
+	if _, ok := resolveStyle(style); !ok {
+		t.Fatal("expected a valid structured style to resolve")
+	}
+
+	if _, ok := resolveStyle(colorStyle{structured: true, fg: "notacolor"}); ok {
+		t.Fatal("expected an unknown fg to fail to resolve")
+	}
+	if _, ok := resolveStyle(colorStyle{structured: true, bg: "notacolor"}); ok {
+		t.Fatal("expected an unknown bg to fail to resolve")
+	}
+	if _, ok := resolveStyle(colorStyle{structured: true, attrs: []string{"notanattr"}}); ok {
+		t.Fatal("expected an unknown attr to fail to resolve")
+	}
+}
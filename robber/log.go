@@ -1,6 +1,8 @@
 package robber
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -10,6 +12,11 @@ import (
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/arukaminado/yar/robber/report/html"
+	"github.com/arukaminado/yar/robber/report/sarif"
 )
 
 const (
@@ -55,15 +62,19 @@ var logColors = map[int]*color.Color{
 }
 
 type jsonFinding []struct {
-	Reason        string `json:"Reason"`
-	Filepath      string `json:"Filepath"`
-	RepoName      string `json:"RepoName"`
-	Commiter      string `json:"Commiter"`
-	CommitHash    string `json:"CommitHash"`
-	DateOfCommit  string `json:"DateOfCommit"`
-	CommitMessage string `json:"CommitMessage"`
-	Source        string `json:"Source"`
-	Secret        string `json:"Secret"`
+	Reason            string `json:"Reason"`
+	Filepath          string `json:"Filepath"`
+	RepoName          string `json:"RepoName"`
+	Commiter          string `json:"Commiter"`
+	CommitHash        string `json:"CommitHash"`
+	DateOfCommit      string `json:"DateOfCommit"`
+	CommitMessage     string `json:"CommitMessage"`
+	Source            string `json:"Source"`
+	Secret            string `json:"Secret"`
+	LastTouchedCommit string `json:"LastTouchedCommit,omitempty"`
+	LastTouchedAuthor string `json:"LastTouchedAuthor,omitempty"`
+	LastTouchedDate   string `json:"LastTouchedDate,omitempty"`
+	StillPresent      bool   `json:"StillPresent"`
 }
 
 // Finding struct contains data of a given secret finding, used for later output of a finding.
@@ -78,6 +89,25 @@ type Finding struct {
 	Diff          string
 	RepoName      string
 	Filepath      string
+
+	// Line is the 1-based line number of the secret within Filepath, as
+	// known by the scanner at detection time. It anchors the file, not the
+	// (possibly trimmed) Diff snippet, so it stays correct regardless of
+	// how much context the snippet carries.
+	Line int
+
+	// LastTouchedCommit, LastTouchedAuthor and LastTouchedDate describe who
+	// last touched the blamed line at HEAD, and StillPresent is true iff
+	// the secret substring is still there verbatim. Populated best-effort
+	// by enrichWithBlame; left zero-valued when blame isn't available.
+	LastTouchedCommit string
+	LastTouchedAuthor string
+	LastTouchedDate   string
+	StillPresent      bool
+
+	// repo is kept around from NewFinding just long enough for LogFinding
+	// to blame HEAD once the full diff context is known.
+	repo *git.Repository
 }
 
 // Logger handles all logging to the output.
@@ -86,33 +116,25 @@ type Logger struct {
 	Verbose bool
 }
 
-func setColors() {
-	colors := GetEnvColors()
-	for colorType := verbose; colorType <= fail; colorType++ {
-		if empty, _ := colors[colorType]; empty == "" {
-			continue
-		}
-		fields := strings.Fields(colors[colorType])
-		if val, ok := validColors[fields[0]]; ok {
-			if len(fields) > 1 && fields[1] == "bold" {
-				logColors[colorType] = val.Add(color.Bold)
-				continue
-			}
-			logColors[colorType] = val
-		}
+// NewLogger loads the theme/config-file/env layered color scheme and
+// returns a new logger. See setColors for precedence rules.
+func NewLogger(flags *Flags) *Logger {
+	verbose := false
+	if flags != nil && flags.Verbose != nil {
+		verbose = *flags.Verbose
 	}
+	l := &Logger{Verbose: verbose}
+	l.setColors(flags)
+	return l
 }
 
-// NewLogger sets all colors as specified and returns a new logger.
-func NewLogger(verbose bool) *Logger {
-	setColors()
-	return &Logger{
-		Verbose: verbose,
-	}
-}
-
-// NewFinding simply returns a new finding struct.
-func NewFinding(reason string, secret []int, diffObject *DiffObject) *Finding {
+// NewFinding simply returns a new finding struct. line is the 1-based line
+// number of the secret within the file, as known by the scanner; it's kept
+// separate from the Diff snippet because Diff may only be a trimmed
+// context window around the match (see logSecret and the --context/
+// --no-context flags), not the whole file, so it can't be used to derive
+// an absolute file line.
+func NewFinding(reason string, secret []int, diffObject *DiffObject, line int) *Finding {
 	finding := &Finding{
 		CommitHash:    diffObject.Commit.Hash.String(),
 		CommitMessage: diffObject.Commit.Message,
@@ -123,10 +145,93 @@ func NewFinding(reason string, secret []int, diffObject *DiffObject) *Finding {
 		Secret:        secret,
 		RepoName:      *diffObject.Reponame,
 		Filepath:      *diffObject.Filepath,
+		Line:          line,
+		repo:          diffObject.Repo,
 	}
 	return finding
 }
 
+// Fingerprint returns a stable identity for the finding (repo, commit,
+// filepath and secret bytes) so reruns and external trackers can dedupe
+// the same finding instead of reporting it over and over.
+func (f *Finding) Fingerprint() string {
+	sum := sha256.Sum256([]byte(f.RepoName + f.CommitHash + f.Filepath + f.Diff[f.Secret[0]:f.Secret[1]]))
+	return hex.EncodeToString(sum[:])
+}
+
+type blameCacheKey struct {
+	repo     string
+	filepath string
+	head     string
+}
+
+var blameCache = struct {
+	sync.Mutex
+	results map[blameCacheKey]*object.BlameResult
+}{results: map[blameCacheKey]*object.BlameResult{}}
+
+func blameAtHead(repo *git.Repository, repoName string, filePath string) (*object.BlameResult, error) {
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	key := blameCacheKey{repo: repoName, filepath: filePath, head: head.Hash().String()}
+	blameCache.Lock()
+	defer blameCache.Unlock()
+	if cached, ok := blameCache.results[key]; ok {
+		return cached, nil
+	}
+
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	result, err := object.Blame(commit, filePath)
+	if err != nil {
+		return nil, err
+	}
+	blameCache.results[key] = result
+	return result, nil
+}
+
+// enrichWithBlame blames the HEAD version of f.Filepath and fills in
+// LastTouchedCommit/Author/Date and StillPresent. It is best-effort: a repo
+// that isn't available locally (e.g. a shallow clone already torn down),
+// or a finding with no known file line, just leaves the fields unset
+// rather than failing the finding. It indexes result.Lines by f.Line (the
+// scanner's real file line, not a position counted within the trimmed
+// Diff snippet) since the latter only locates the secret within a context
+// window, not the file.
+func enrichWithBlame(f *Finding) {
+	if f.repo == nil || f.Line <= 0 {
+		return
+	}
+	result, err := blameAtHead(f.repo, f.RepoName, f.Filepath)
+	if err != nil {
+		return
+	}
+
+	line := f.Line - 1
+	if line < 0 || line >= len(result.Lines) {
+		return
+	}
+
+	blamedLine := result.Lines[line]
+	f.LastTouchedCommit = blamedLine.Hash.String()
+	f.LastTouchedAuthor = blamedLine.Author
+	f.LastTouchedDate = blamedLine.Date.Format(time.RFC1123)
+	f.StillPresent = secretStillPresent(blamedLine.Text, f.Diff[f.Secret[0]:f.Secret[1]])
+}
+
+// secretStillPresent reports whether secret still appears verbatim in
+// lineText, the current HEAD content of the blamed line. Split out of
+// enrichWithBlame so this bit of the blame logic can be unit tested without
+// a real git repository to blame against.
+func secretStillPresent(lineText, secret string) bool {
+	return strings.Contains(lineText, secret)
+}
+
 func saveFindingsHelper(repoName string, hash string, filePath string) string {
 	if strings.HasPrefix(repoName, "/tmp") {
 		return fmt.Sprintf("git --git-dir=%s show %s:%s", repoName, hash[:6], filePath)
@@ -150,12 +255,68 @@ func SaveFindings(m *Middleware) {
 			CommitMessage: finding.CommitMessage,
 			Source:        source,
 			Secret:        finding.Diff[finding.Secret[0]:finding.Secret[1]],
+
+			LastTouchedCommit: finding.LastTouchedCommit,
+			LastTouchedAuthor: finding.LastTouchedAuthor,
+			LastTouchedDate:   finding.LastTouchedDate,
+			StillPresent:      finding.StillPresent,
 		}}...)
 	}
 	content, _ := json.MarshalIndent(savedFindings, "", "  ")
 	_ = ioutil.WriteFile(*m.Flags.Save, content, 0644)
 }
 
+// SaveSarif saves all findings as a SARIF 2.1.0 log to the path given by the
+// --sarif flag, for consumption by code scanning tools.
+func SaveSarif(m *Middleware) {
+	inputs := make([]sarif.Input, len(m.Findings))
+	for i, finding := range m.Findings {
+		inputs[i] = sarif.Input{
+			Reason:       finding.Reason,
+			Filepath:     finding.Filepath,
+			Committer:    finding.Committer,
+			DateOfCommit: finding.DateOfCommit,
+			Diff:         finding.Diff,
+			Secret:       [2]int{finding.Secret[0], finding.Secret[1]},
+			Fingerprint:  finding.Fingerprint(),
+		}
+	}
+	content, _ := json.MarshalIndent(sarif.Build(inputs), "", "  ")
+	_ = ioutil.WriteFile(*m.Flags.Sarif, content, 0644)
+}
+
+// SaveHTML renders all findings as a single self-contained HTML report to
+// the path given by the --html flag.
+func SaveHTML(m *Middleware) {
+	inputs := make([]html.Input, len(m.Findings))
+	for i, finding := range m.Findings {
+		repoPath, _ := GetDir(finding.RepoName)
+		inputs[i] = html.Input{
+			Reason:            finding.Reason,
+			RepoName:          finding.RepoName,
+			Filepath:          finding.Filepath,
+			Committer:         finding.Committer,
+			Email:             finding.Email,
+			DateOfCommit:      finding.DateOfCommit,
+			CommitHash:        finding.CommitHash,
+			Diff:              finding.Diff,
+			Secret:            [2]int{finding.Secret[0], finding.Secret[1]},
+			Fingerprint:       finding.Fingerprint(),
+			ShowCommand:       fmt.Sprintf("git --git-dir=%s show %s:%s", repoPath, finding.CommitHash[:6], finding.Filepath),
+			LastTouchedCommit: finding.LastTouchedCommit,
+			LastTouchedAuthor: finding.LastTouchedAuthor,
+			LastTouchedDate:   finding.LastTouchedDate,
+			StillPresent:      finding.StillPresent,
+		}
+	}
+
+	content, err := html.Build(inputs)
+	if err != nil {
+		return
+	}
+	_ = ioutil.WriteFile(*m.Flags.HTML, content, 0644)
+}
+
 func (l *Logger) log(level int, format string, a ...interface{}) {
 	l.Lock()
 	defer l.Unlock()
@@ -188,7 +349,12 @@ func (l *Logger) LogFinding(f *Finding, m *Middleware, contextDiff string) {
 	l.Lock()
 	defer l.Unlock()
 	f.Diff = contextDiff
+	enrichWithBlame(f)
+	if m.Flags.OnlyLive != nil && *m.Flags.OnlyLive && !f.StillPresent {
+		return
+	}
 	m.Append(f)
+	m.Reporters.Dispatch(f)
 
 	info, _ := logColors[info]
 	data, _ := logColors[data]
@@ -214,6 +380,10 @@ func (l *Logger) LogFinding(f *Finding, m *Middleware, contextDiff string) {
 	data.Println(f.DateOfCommit)
 	info.Printf("Commit message: ")
 	data.Printf("%s\n\n", strings.Trim(f.CommitMessage, "\n"))
+	if f.LastTouchedCommit != "" {
+		info.Printf("Last touched: ")
+		data.Printf("%s by %s on %s\n\n", f.LastTouchedCommit[:6], f.LastTouchedAuthor, f.LastTouchedDate)
+	}
 	if *m.Flags.NoContext {
 		secret.Printf("%s\n\n", contextDiff[f.Secret[0]:f.Secret[1]])
 	} else {
@@ -0,0 +1,17 @@
+// Package redact masks secret values before they're written somewhere with
+// a broader audience than the scanned repo itself (SARIF uploads, issue
+// trackers, HTML reports). It has no dependency on robber so report and
+// reporter packages can use it without importing back into robber.
+package redact
+
+import "strings"
+
+// Secret returns a redacted form of s: short values are fully masked,
+// longer ones keep a couple of characters on each end so findings can
+// still be told apart without exposing the value.
+func Secret(s string) string {
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:2] + strings.Repeat("*", len(s)-4) + s[len(s)-2:]
+}
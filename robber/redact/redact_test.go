@@ -0,0 +1,24 @@
+package redact
+
+import "testing"
+
+func TestSecret(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "", ""},
+		{"short kept fully masked", "abcd", "****"},
+		{"longer keeps two chars each end", "abcdef", "ab**ef"},
+		{"long secret", "sk-1234567890abcdef", "sk***************ef"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Secret(c.in); got != c.want {
+				t.Errorf("Secret(%q) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
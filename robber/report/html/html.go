@@ -0,0 +1,140 @@
+// Package html renders yar findings as a single self-contained static HTML
+// report: a sidebar of repos -> files -> findings, and a detail pane per
+// finding with the terminal-styled diff preserved.
+package html
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+
+	terminal "github.com/buildkite/terminal-to-html/v3"
+)
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+//go:embed assets/report.css
+var reportCSS string
+
+//go:embed assets/report.js
+var reportJS string
+
+// ANSI escapes matching the console "data"/"secret" colors (logSecret in
+// robber/log.go), so the report's diff rendering reads the same as a
+// terminal scan.
+const (
+	ansiData   = "\x1b[94m"
+	ansiSecret = "\x1b[1;93m"
+	ansiReset  = "\x1b[0m"
+)
+
+// Input is everything buildViewModel needs to place one finding in the
+// report: enough to group it under its repo and file in the sidebar, render
+// its diff with the secret highlighted, and fill in its detail pane
+// (including the blame fields, when available). Package robber populates
+// one per Finding in SaveHTML.
+type Input struct {
+	Reason            string
+	RepoName          string
+	Filepath          string
+	Committer         string
+	Email             string
+	DateOfCommit      string
+	CommitHash        string
+	Diff              string
+	Secret            [2]int
+	Fingerprint       string
+	ShowCommand       string
+	LastTouchedCommit string
+	LastTouchedAuthor string
+	LastTouchedDate   string
+	StillPresent      bool
+}
+
+type finding struct {
+	Input
+	AnchorID string
+	Secret   string
+	DiffHTML template.HTML
+}
+
+type fileGroup struct {
+	Filepath string
+	Findings []finding
+}
+
+type repoGroup struct {
+	RepoName string
+	Files    []fileGroup
+}
+
+type viewModel struct {
+	Repos    []repoGroup
+	Findings []finding
+	CSS      template.CSS
+	JS       template.JS
+}
+
+func diffHTML(in Input) template.HTML {
+	ansi := ansiData + in.Diff[:in.Secret[0]] + ansiReset +
+		ansiSecret + in.Diff[in.Secret[0]:in.Secret[1]] + ansiReset +
+		ansiData + in.Diff[in.Secret[1]:] + ansiReset
+
+	return template.HTML(terminal.Render([]byte(ansi)))
+}
+
+func buildViewModel(inputs []Input) *viewModel {
+	vm := &viewModel{CSS: template.CSS(reportCSS), JS: template.JS(reportJS)}
+
+	repoIndex := map[string]int{}
+	fileIndex := map[string]int{}
+
+	for i, in := range inputs {
+		view := finding{
+			Input: in,
+			// AnchorID disambiguates findings that share a Fingerprint
+			// (e.g. two reasons matching the same secret span) so the
+			// sidebar's anchor links don't collide on duplicate ids.
+			AnchorID: fmt.Sprintf("%s-%d", in.Fingerprint, i),
+			Secret:   in.Diff[in.Secret[0]:in.Secret[1]],
+			DiffHTML: diffHTML(in),
+		}
+		vm.Findings = append(vm.Findings, view)
+
+		ri, ok := repoIndex[in.RepoName]
+		if !ok {
+			ri = len(vm.Repos)
+			repoIndex[in.RepoName] = ri
+			vm.Repos = append(vm.Repos, repoGroup{RepoName: in.RepoName})
+		}
+
+		fileKey := in.RepoName + "\x00" + in.Filepath
+		fi, ok := fileIndex[fileKey]
+		if !ok {
+			fi = len(vm.Repos[ri].Files)
+			fileIndex[fileKey] = fi
+			vm.Repos[ri].Files = append(vm.Repos[ri].Files, fileGroup{Filepath: in.Filepath})
+		}
+		vm.Repos[ri].Files[fi].Findings = append(vm.Repos[ri].Files[fi].Findings, view)
+	}
+
+	return vm
+}
+
+// Build renders findings into a single self-contained HTML document.
+func Build(inputs []Input) ([]byte, error) {
+	vm := buildViewModel(inputs)
+
+	tmpl, err := template.New("report").Parse(reportTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vm); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
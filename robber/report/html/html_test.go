@@ -0,0 +1,52 @@
+package html
+
+import "testing"
+
+func input(repo, file, secret, fingerprint string) Input {
+	diff := "prefix:" + secret + ":suffix"
+	start := len("prefix:")
+	return Input{
+		Reason:      "Generic Secret",
+		RepoName:    repo,
+		Filepath:    file,
+		Diff:        diff,
+		Secret:      [2]int{start, start + len(secret)},
+		Fingerprint: fingerprint,
+	}
+}
+
+func TestBuildViewModelGroupsByRepoAndFile(t *testing.T) {
+	vm := buildViewModel([]Input{
+		input("repoA", "a.go", "secret1", "fp1"),
+		input("repoA", "a.go", "secret2", "fp2"),
+		input("repoA", "b.go", "secret3", "fp3"),
+		input("repoB", "c.go", "secret4", "fp4"),
+	})
+
+	if len(vm.Repos) != 2 {
+		t.Fatalf("expected 2 repo groups, got %d", len(vm.Repos))
+	}
+	if vm.Repos[0].RepoName != "repoA" || len(vm.Repos[0].Files) != 2 {
+		t.Fatalf("expected repoA with 2 files, got %+v", vm.Repos[0])
+	}
+	if len(vm.Repos[0].Files[0].Findings) != 2 {
+		t.Fatalf("expected a.go to have 2 findings, got %d", len(vm.Repos[0].Files[0].Findings))
+	}
+	if vm.Repos[1].RepoName != "repoB" || len(vm.Repos[1].Files) != 1 {
+		t.Fatalf("expected repoB with 1 file, got %+v", vm.Repos[1])
+	}
+}
+
+func TestBuildViewModelDedupesAnchorsOnSharedFingerprint(t *testing.T) {
+	vm := buildViewModel([]Input{
+		input("repoA", "a.go", "secret1", "shared"),
+		input("repoA", "a.go", "secret1", "shared"),
+	})
+
+	if len(vm.Findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d", len(vm.Findings))
+	}
+	if vm.Findings[0].AnchorID == vm.Findings[1].AnchorID {
+		t.Fatalf("expected distinct anchors for shared fingerprint, both were %q", vm.Findings[0].AnchorID)
+	}
+}
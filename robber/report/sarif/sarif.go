@@ -0,0 +1,179 @@
+// Package sarif renders yar findings as a SARIF 2.1.0 log so results can be
+// uploaded to GitHub code scanning, Azure DevOps, or any other SARIF-consuming
+// viewer.
+package sarif
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/arukaminado/yar/robber/redact"
+)
+
+const schemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+const version = "2.1.0"
+const toolName = "yar"
+const informationURI = "https://github.com/arukaminado/yar"
+
+// ToolVersion is stamped at build time via -ldflags, falling back to "dev".
+var ToolVersion = "dev"
+
+var slugRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Log is the top level SARIF document.
+type Log struct {
+	Schema string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run describes a single invocation of a tool.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+// Tool wraps the driver that produced the run.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver describes the analysis tool and the rules it can report.
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	InformationURI string `json:"informationUri"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule describes one distinct finding reason.
+type Rule struct {
+	ID                   string        `json:"id"`
+	ShortDescription     Message       `json:"shortDescription"`
+	DefaultConfiguration Configuration `json:"defaultConfiguration"`
+}
+
+// Configuration carries the default severity level for a rule.
+type Configuration struct {
+	Level string `json:"level"`
+}
+
+// Message is a freeform SARIF text container.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result is a single finding reported against a rule.
+type Result struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             Message           `json:"message"`
+	Locations           []Location        `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+// Location pins a result to a place in a file.
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation is the artifact and region a result was found in.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           Region           `json:"region"`
+}
+
+// ArtifactLocation identifies the scanned file.
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Region is a 1-based line range with a redacted snippet.
+type Region struct {
+	StartLine int     `json:"startLine"`
+	EndLine   int     `json:"endLine"`
+	Snippet   Message `json:"snippet"`
+}
+
+func slugify(reason string) string {
+	slug := slugRe.ReplaceAllString(strings.ToLower(reason), "-")
+	return "yar." + strings.Trim(slug, "-")
+}
+
+func lineOf(diff string, offset int) int {
+	return strings.Count(diff[:offset], "\n") + 1
+}
+
+// Input is the subset of a finding Build needs to produce one SARIF Result:
+// enough to slug a ruleId from Reason, locate the secret's line range within
+// Diff, and describe who committed it. Package robber populates one per
+// Finding in SaveSarif.
+type Input struct {
+	Reason       string
+	Filepath     string
+	Committer    string
+	DateOfCommit string
+	Diff         string
+	Secret       [2]int
+	Fingerprint  string
+}
+
+// Build converts findings into a SARIF 2.1.0 Log with a single run.
+func Build(findings []Input) *Log {
+	rules := make(map[string]Rule)
+	var results []Result
+
+	for _, f := range findings {
+		id := slugify(f.Reason)
+		if _, ok := rules[id]; !ok {
+			rules[id] = Rule{
+				ID:                   id,
+				ShortDescription:     Message{Text: f.Reason},
+				DefaultConfiguration: Configuration{Level: "error"},
+			}
+		}
+
+		results = append(results, Result{
+			RuleID: id,
+			Level:  "error",
+			Message: Message{
+				Text: fmt.Sprintf("%s (committed by %s on %s)", f.Reason, f.Committer, f.DateOfCommit),
+			},
+			Locations: []Location{{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: f.Filepath},
+					Region: Region{
+						StartLine: lineOf(f.Diff, f.Secret[0]),
+						EndLine:   lineOf(f.Diff, f.Secret[1]),
+						Snippet:   Message{Text: redact.Secret(f.Diff[f.Secret[0]:f.Secret[1]])},
+					},
+				},
+			}},
+			PartialFingerprints: map[string]string{
+				"yarSecretHash/v1": f.Fingerprint,
+			},
+		})
+	}
+
+	driverRules := make([]Rule, 0, len(rules))
+	for _, r := range rules {
+		driverRules = append(driverRules, r)
+	}
+
+	return &Log{
+		Schema:  schemaURI,
+		Version: version,
+		Runs: []Run{{
+			Tool: Tool{
+				Driver: Driver{
+					Name:           toolName,
+					Version:        ToolVersion,
+					InformationURI: informationURI,
+					Rules:          driverRules,
+				},
+			},
+			Results: results,
+		}},
+	}
+}
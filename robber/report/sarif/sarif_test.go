@@ -0,0 +1,63 @@
+package sarif
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"AWS Access Key":     "yar.aws-access-key",
+		"Generic API Key!!!": "yar.generic-api-key",
+		"already-slug":       "yar.already-slug",
+	}
+	for in, want := range cases {
+		if got := slugify(in); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLineOf(t *testing.T) {
+	diff := "line one\nline two\nline three"
+	cases := []struct {
+		offset int
+		want   int
+	}{
+		{0, 1},
+		{9, 2},
+		{18, 3},
+	}
+	for _, c := range cases {
+		if got := lineOf(diff, c.offset); got != c.want {
+			t.Errorf("lineOf(diff, %d) = %d, want %d", c.offset, got, c.want)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	diff := "key := \"sk-abcdefghijklmnop\""
+	start, end := 8, len(diff)-1
+	log := Build([]Input{{
+		Reason:       "AWS Access Key",
+		Filepath:     "config.go",
+		Committer:    "Jane Doe",
+		DateOfCommit: "yesterday",
+		Diff:         diff,
+		Secret:       [2]int{start, end},
+		Fingerprint:  "deadbeef",
+	}})
+
+	if log.Version != version {
+		t.Fatalf("Version = %q, want %q", log.Version, version)
+	}
+	if len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("expected exactly one run with one result, got %+v", log.Runs)
+	}
+
+	result := log.Runs[0].Results[0]
+	if result.RuleID != "yar.aws-access-key" {
+		t.Errorf("RuleID = %q, want yar.aws-access-key", result.RuleID)
+	}
+	snippet := result.Locations[0].PhysicalLocation.Region.Snippet.Text
+	if snippet == diff[start:end] {
+		t.Errorf("snippet %q was not redacted", snippet)
+	}
+}
@@ -0,0 +1,65 @@
+package reporters
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileReporterConfig is one entry under a config file's reporters: section,
+// e.g.:
+//
+//	reporters:
+//	  - name: slack
+//	    config:
+//	      webhook: ${SLACK_WEBHOOK_URL}
+type fileReporterConfig struct {
+	Name   string            `yaml:"name"`
+	Config map[string]string `yaml:"config"`
+}
+
+// reportersFileConfig is the shape of the reporters: section of
+// ~/.yar/config.yaml (see colorsFileConfig in package robber for the
+// sibling colors: section of the same file).
+type reportersFileConfig struct {
+	Reporters []fileReporterConfig `yaml:"reporters"`
+}
+
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".yar", "config.yaml")
+}
+
+// loadReportersFileConfig reads the reporters: section from path, starting
+// fresh if the file doesn't exist yet. Config values are expanded the same
+// way as --reporter flag values, so secrets can live in the environment
+// rather than the config file itself.
+func loadReportersFileConfig(path string) (*reportersFileConfig, error) {
+	cfg := &reportersFileConfig{}
+	if path == "" {
+		return cfg, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return nil, err
+	}
+
+	for i, entry := range cfg.Reporters {
+		for key, val := range entry.Config {
+			cfg.Reporters[i].Config[key] = expandEnv(val)
+		}
+	}
+	return cfg, nil
+}
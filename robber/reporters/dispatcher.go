@@ -0,0 +1,153 @@
+package reporters
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/arukaminado/yar/robber"
+)
+
+const queueSize = 256
+const defaultWorkers = 4
+
+// reportTimeout bounds how long a single Report call may run. Without it, a
+// reporter backed by an unresponsive endpoint hangs its worker forever;
+// once enough findings back up, the queue fills and Dispatch (called
+// synchronously from LogFinding) blocks the scan itself.
+const reportTimeout = 15 * time.Second
+
+// Dispatcher fans findings out to every configured Reporter through a
+// buffered channel and a small worker pool, so a slow or unreachable remote
+// tracker can't stall the scan itself.
+type Dispatcher struct {
+	reporters []Reporter
+	state     *State
+	queue     chan *robber.Finding
+	wg        sync.WaitGroup
+}
+
+// NewDispatcher builds a Dispatcher from repeatable --reporter specs of the
+// form "name:key=val,...", plus any reporters: entries found in the YAML
+// config at configPath (configPath == "" loads none; see
+// loadReportersFileConfig). It loads/persists dedupe state to statePath.
+func NewDispatcher(specs []string, configPath string, statePath string) (*Dispatcher, error) {
+	state, err := LoadState(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Dispatcher{
+		state: state,
+		queue: make(chan *robber.Finding, queueSize),
+	}
+
+	for _, spec := range specs {
+		name, cfg, err := ParseSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.addReporter(name, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	fileCfg, err := loadReportersFileConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range fileCfg.Reporters {
+		if err := d.addReporter(entry.Name, entry.Config); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := 0; i < defaultWorkers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+	return d, nil
+}
+
+func (d *Dispatcher) addReporter(name string, cfg map[string]string) error {
+	r, ok := New(name)
+	if !ok {
+		return unknownReporterError(name)
+	}
+	if err := r.Init(cfg); err != nil {
+		return err
+	}
+	d.reporters = append(d.reporters, r)
+	return nil
+}
+
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+	for f := range d.queue {
+		fingerprint := f.Fingerprint()
+		if d.state.Seen(fingerprint) {
+			continue
+		}
+
+		// Only mark the fingerprint seen once at least one reporter actually
+		// took it. Marking unconditionally would mean a finding that every
+		// reporter failed to deliver (a transient network blip, a sink that
+		// was down) is dropped for good instead of retried on the next scan.
+		var delivered bool
+		for _, r := range d.reporters {
+			if err := d.report(r, f); err != nil {
+				fmt.Fprintf(os.Stderr, "reporters: %s: %v\n", r.Name(), err)
+				continue
+			}
+			delivered = true
+		}
+		if !delivered {
+			continue
+		}
+		if err := d.state.Mark(fingerprint); err != nil {
+			fmt.Fprintf(os.Stderr, "reporters: could not persist dedupe state: %v\n", err)
+		}
+	}
+}
+
+// report runs a single Reporter.Report call under reportTimeout, so one
+// unresponsive endpoint can't tie up a worker indefinitely.
+func (d *Dispatcher) report(r Reporter, f *robber.Finding) error {
+	ctx, cancel := context.WithTimeout(context.Background(), reportTimeout)
+	defer cancel()
+	return r.Report(ctx, f)
+}
+
+// Dispatch queues a finding to be reported asynchronously. It returns
+// immediately; reporting happens on the Dispatcher's worker pool.
+func (d *Dispatcher) Dispatch(f *robber.Finding) {
+	if d == nil || len(d.reporters) == 0 {
+		return
+	}
+	d.queue <- f
+}
+
+// Flush drains the queue, waits for all in-flight reports to finish, and
+// flushes every configured reporter.
+func (d *Dispatcher) Flush() error {
+	if d == nil {
+		return nil
+	}
+	close(d.queue)
+	d.wg.Wait()
+
+	for _, r := range d.reporters {
+		if err := r.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type unknownReporterError string
+
+func (e unknownReporterError) Error() string {
+	return "reporters: unknown reporter " + string(e)
+}
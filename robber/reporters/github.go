@@ -0,0 +1,81 @@
+package reporters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/arukaminado/yar/robber"
+	"github.com/arukaminado/yar/robber/redact"
+)
+
+func init() {
+	Register("github", func() Reporter { return &GitHubReporter{client: newHTTPClient()} })
+}
+
+// GitHubReporter opens a GitHub issue for every finding it is sent.
+// Configure it with "github:token=${GITHUB_TOKEN},owner=acme,repo=widgets".
+type GitHubReporter struct {
+	client *http.Client
+	token  string
+	owner  string
+	repo   string
+}
+
+// Name returns the reporter's registered name.
+func (g *GitHubReporter) Name() string { return "github" }
+
+// Init reads the token, owner and repo out of cfg.
+func (g *GitHubReporter) Init(cfg map[string]string) error {
+	g.token = cfg["token"]
+	g.owner = cfg["owner"]
+	g.repo = cfg["repo"]
+	if g.token == "" || g.owner == "" || g.repo == "" {
+		return fmt.Errorf("reporters: github requires token, owner and repo")
+	}
+	return nil
+}
+
+// Report opens a new issue describing the finding.
+func (g *GitHubReporter) Report(ctx context.Context, f *robber.Finding) error {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues", g.owner, g.repo)
+	body, err := json.Marshal(map[string]string{
+		"title": fmt.Sprintf("[yar] %s in %s", f.Reason, f.Filepath),
+		"body":  findingBody(f),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("reporters: github: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Flush is a no-op; the GitHub reporter has nothing to buffer.
+func (g *GitHubReporter) Flush() error { return nil }
+
+// findingBody renders the issue body shared by the GitHub and GitLab
+// reporters. The secret itself is redacted: issue trackers are often more
+// broadly visible than the repo the secret was found in, so reporting a
+// finding must not republish the live value.
+func findingBody(f *robber.Finding) string {
+	return fmt.Sprintf("Committer: %s\nCommit: %s\nDate: %s\n\n```\n%s\n```",
+		f.Committer, f.CommitHash, f.DateOfCommit, redact.Secret(f.Diff[f.Secret[0]:f.Secret[1]]))
+}
@@ -0,0 +1,76 @@
+package reporters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/arukaminado/yar/robber"
+)
+
+func init() {
+	Register("gitlab", func() Reporter { return &GitLabReporter{client: newHTTPClient()} })
+}
+
+// GitLabReporter opens a GitLab issue for every finding it is sent.
+// Configure it with "gitlab:token=${GITLAB_TOKEN},project=42" (project is
+// the numeric or URL-encoded project ID) and optionally "baseURL" for
+// self-hosted instances.
+type GitLabReporter struct {
+	client  *http.Client
+	token   string
+	project string
+	baseURL string
+}
+
+// Name returns the reporter's registered name.
+func (g *GitLabReporter) Name() string { return "gitlab" }
+
+// Init reads the token, project and optional baseURL out of cfg.
+func (g *GitLabReporter) Init(cfg map[string]string) error {
+	g.token = cfg["token"]
+	g.project = cfg["project"]
+	g.baseURL = cfg["baseURL"]
+	if g.baseURL == "" {
+		g.baseURL = "https://gitlab.com"
+	}
+	if g.token == "" || g.project == "" {
+		return fmt.Errorf("reporters: gitlab requires token and project")
+	}
+	return nil
+}
+
+// Report opens a new issue describing the finding.
+func (g *GitLabReporter) Report(ctx context.Context, f *robber.Finding) error {
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/issues", g.baseURL, url.PathEscape(g.project))
+	body, err := json.Marshal(map[string]string{
+		"title":       fmt.Sprintf("[yar] %s in %s", f.Reason, f.Filepath),
+		"description": findingBody(f),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("PRIVATE-TOKEN", g.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("reporters: gitlab: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Flush is a no-op; the GitLab reporter has nothing to buffer.
+func (g *GitLabReporter) Flush() error { return nil }
@@ -0,0 +1,93 @@
+package reporters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/arukaminado/yar/robber"
+	"github.com/arukaminado/yar/robber/redact"
+)
+
+func init() {
+	Register("http", func() Reporter { return &HTTPReporter{client: newHTTPClient()} })
+}
+
+// HTTPReporter POSTs each finding as JSON to a generic sink. Configure it
+// with "http:url=${SINK_URL}" and optionally "authHeader=${SINK_TOKEN}" for
+// a bearer token.
+type HTTPReporter struct {
+	client     *http.Client
+	url        string
+	authHeader string
+}
+
+// Name returns the reporter's registered name.
+func (h *HTTPReporter) Name() string { return "http" }
+
+// Init reads the sink URL and optional auth header out of cfg.
+func (h *HTTPReporter) Init(cfg map[string]string) error {
+	h.url = cfg["url"]
+	h.authHeader = cfg["authHeader"]
+	if h.url == "" {
+		return fmt.Errorf("reporters: http requires url")
+	}
+	return nil
+}
+
+// findingPayload is what the http reporter actually sends. Unlike the
+// GitHub/GitLab/Slack reporters, url is operator-supplied and often points
+// at infrastructure less trusted than the tracker a maintainer would pick,
+// so the payload carries a redacted secret rather than the raw Finding
+// (which embeds the live value in Diff).
+type findingPayload struct {
+	Reason       string `json:"reason"`
+	RepoName     string `json:"repoName"`
+	Filepath     string `json:"filepath"`
+	Committer    string `json:"committer"`
+	CommitHash   string `json:"commitHash"`
+	DateOfCommit string `json:"dateOfCommit"`
+	Secret       string `json:"secret"`
+	Fingerprint  string `json:"fingerprint"`
+}
+
+// Report POSTs the finding to the configured sink as JSON.
+func (h *HTTPReporter) Report(ctx context.Context, f *robber.Finding) error {
+	body, err := json.Marshal(findingPayload{
+		Reason:       f.Reason,
+		RepoName:     f.RepoName,
+		Filepath:     f.Filepath,
+		Committer:    f.Committer,
+		CommitHash:   f.CommitHash,
+		DateOfCommit: f.DateOfCommit,
+		Secret:       redact.Secret(f.Diff[f.Secret[0]:f.Secret[1]]),
+		Fingerprint:  f.Fingerprint(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.authHeader != "" {
+		req.Header.Set("Authorization", "Bearer "+h.authHeader)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("reporters: http: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Flush is a no-op; the HTTP reporter has nothing to buffer.
+func (h *HTTPReporter) Flush() error { return nil }
@@ -0,0 +1,53 @@
+// Package reporters implements a pluggable bridge subsystem, modelled on
+// git-bug's bridge pattern, for pushing yar findings to external issue
+// trackers and chat tools as they are found.
+package reporters
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arukaminado/yar/robber"
+)
+
+// newHTTPClient returns an http.Client bounded by reportTimeout. Reporters
+// must use this instead of http.DefaultClient, which has no timeout and
+// lets an unresponsive endpoint hang a worker indefinitely.
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: reportTimeout}
+}
+
+// Reporter pushes a single finding to an external tracker. Implementations
+// are registered by name via Register and instantiated on demand from the
+// repeatable --reporter flag.
+type Reporter interface {
+	// Name returns the registered name of the reporter, e.g. "github".
+	Name() string
+	// Init configures the reporter from the key=val pairs given after the
+	// reporter name on the command line (or a YAML config section).
+	Init(cfg map[string]string) error
+	// Report pushes a single finding. It must be safe to call concurrently.
+	Report(ctx context.Context, f *robber.Finding) error
+	// Flush blocks until any buffered work has been sent and releases
+	// resources held by the reporter.
+	Flush() error
+}
+
+var factories = map[string]func() Reporter{}
+
+// Register makes a reporter factory available under name for use with the
+// --reporter flag. It is expected to be called from an init() in the file
+// that implements the reporter.
+func Register(name string, factory func() Reporter) {
+	factories[name] = factory
+}
+
+// New instantiates the reporter registered under name, or returns false if
+// no such reporter exists.
+func New(name string) (Reporter, bool) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, false
+	}
+	return factory(), true
+}
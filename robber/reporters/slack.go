@@ -0,0 +1,65 @@
+package reporters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/arukaminado/yar/robber"
+)
+
+func init() {
+	Register("slack", func() Reporter { return &SlackReporter{client: newHTTPClient()} })
+}
+
+// SlackReporter posts a message to a Slack incoming webhook for every
+// finding it is sent. Configure it with
+// "slack:webhook=${SLACK_WEBHOOK_URL}".
+type SlackReporter struct {
+	client  *http.Client
+	webhook string
+}
+
+// Name returns the reporter's registered name.
+func (s *SlackReporter) Name() string { return "slack" }
+
+// Init reads the webhook URL out of cfg.
+func (s *SlackReporter) Init(cfg map[string]string) error {
+	s.webhook = cfg["webhook"]
+	if s.webhook == "" {
+		return fmt.Errorf("reporters: slack requires webhook")
+	}
+	return nil
+}
+
+// Report posts the finding to the configured webhook as a Slack message.
+func (s *SlackReporter) Report(ctx context.Context, f *robber.Finding) error {
+	body, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf(":rotating_light: *%s* in `%s` (%s, committed by %s)",
+			f.Reason, f.Filepath, f.CommitHash[:6], f.Committer),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("reporters: slack: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Flush is a no-op; the Slack reporter has nothing to buffer.
+func (s *SlackReporter) Flush() error { return nil }
@@ -0,0 +1,42 @@
+package reporters
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var envRef = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// ParseSpec splits a --reporter flag value of the form
+// "name:key=val,key=val" into the reporter name and its config map. Values
+// containing "${VAR}" are interpolated from the environment so tokens never
+// have to be written to disk.
+func ParseSpec(spec string) (string, map[string]string, error) {
+	name, rest, ok := strings.Cut(spec, ":")
+	if !ok {
+		return spec, map[string]string{}, nil
+	}
+
+	cfg := make(map[string]string)
+	if rest == "" {
+		return name, cfg, nil
+	}
+
+	for _, pair := range strings.Split(rest, ",") {
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok {
+			return "", nil, fmt.Errorf("reporters: malformed config %q in spec %q", pair, spec)
+		}
+		cfg[key] = expandEnv(val)
+	}
+	return name, cfg, nil
+}
+
+func expandEnv(val string) string {
+	return envRef.ReplaceAllStringFunc(val, func(ref string) string {
+		name := envRef.FindStringSubmatch(ref)[1]
+		return os.Getenv(name)
+	})
+}
@@ -0,0 +1,66 @@
+package reporters
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseSpec(t *testing.T) {
+	t.Setenv("YAR_TEST_TOKEN", "s3cr3t")
+
+	cases := []struct {
+		name     string
+		spec     string
+		wantName string
+		wantCfg  map[string]string
+	}{
+		{"name only", "slack", "slack", map[string]string{}},
+		{"single pair", "slack:webhook=https://example.com", "slack", map[string]string{"webhook": "https://example.com"}},
+		{
+			"multiple pairs with env expansion",
+			"github:token=${YAR_TEST_TOKEN},owner=acme,repo=widgets",
+			"github",
+			map[string]string{"token": "s3cr3t", "owner": "acme", "repo": "widgets"},
+		},
+		{"trailing colon, no pairs", "slack:", "slack", map[string]string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			name, cfg, err := ParseSpec(c.spec)
+			if err != nil {
+				t.Fatalf("ParseSpec(%q) returned error: %v", c.spec, err)
+			}
+			if name != c.wantName {
+				t.Errorf("name = %q, want %q", name, c.wantName)
+			}
+			if !reflect.DeepEqual(cfg, c.wantCfg) {
+				t.Errorf("cfg = %#v, want %#v", cfg, c.wantCfg)
+			}
+		})
+	}
+}
+
+func TestParseSpecMalformed(t *testing.T) {
+	if _, _, err := ParseSpec("slack:webhook"); err == nil {
+		t.Fatal("expected an error for a config pair with no \"=\"")
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	os.Unsetenv("YAR_TEST_UNSET")
+	t.Setenv("YAR_TEST_SET", "value")
+
+	cases := map[string]string{
+		"no refs":                "no refs",
+		"${YAR_TEST_SET}":        "value",
+		"prefix-${YAR_TEST_SET}": "prefix-value",
+		"${YAR_TEST_UNSET}":      "",
+	}
+	for in, want := range cases {
+		if got := expandEnv(in); got != want {
+			t.Errorf("expandEnv(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package reporters
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// State tracks which finding fingerprints have already been reported, so
+// restarting a scan against the same repos doesn't post duplicates.
+type State struct {
+	mu   sync.Mutex
+	path string
+	seen map[string]bool
+}
+
+// LoadState reads the dedupe state from path, starting fresh if the file
+// does not exist yet.
+func LoadState(path string) (*State, error) {
+	s := &State{path: path, seen: map[string]bool{}}
+
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &s.seen); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Seen reports whether fingerprint has already been reported.
+func (s *State) Seen(fingerprint string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seen[fingerprint]
+}
+
+// Mark records fingerprint as reported and persists the state to disk.
+func (s *State) Mark(fingerprint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen[fingerprint] = true
+
+	content, err := json.MarshalIndent(s.seen, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.path, content, 0644)
+}
@@ -0,0 +1,48 @@
+package reporters
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStateSeenAndMark(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	s, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if s.Seen("fp1") {
+		t.Fatal("fresh state should not have seen fp1")
+	}
+
+	if err := s.Mark("fp1"); err != nil {
+		t.Fatalf("Mark: %v", err)
+	}
+	if !s.Seen("fp1") {
+		t.Fatal("fp1 should be seen after Mark")
+	}
+	if s.Seen("fp2") {
+		t.Fatal("marking fp1 should not affect fp2")
+	}
+}
+
+func TestStatePersistsAcrossLoads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	s, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if err := s.Mark("fp1"); err != nil {
+		t.Fatalf("Mark: %v", err)
+	}
+
+	reloaded, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState (reload): %v", err)
+	}
+	if !reloaded.Seen("fp1") {
+		t.Fatal("fp1 should still be seen after reloading state from disk")
+	}
+}